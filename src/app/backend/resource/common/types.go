@@ -0,0 +1,81 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// ResourceKind is the name of the resource/kind that is being exposed by the API, e.g. "pod" or
+// "horizontalpodautoscaler". Used in TypeMeta so that the frontend can tell what it is looking at.
+type ResourceKind string
+
+// List of all resource kinds supported by the UI.
+const (
+	ResourceKindHorizontalPodAutoscaler = "horizontalpodautoscaler"
+	ResourceKindNode                    = "node"
+	ResourceKindPod                     = "pod"
+	ResourceKindReplicaSet              = "replicaset"
+	ResourceKindReplicationController   = "replicationcontroller"
+)
+
+// Heapster metric names of the standard CPU and memory usage metrics.
+const (
+	CpuUsage    = "cpu/usage_rate"
+	MemoryUsage = "memory/usage"
+)
+
+// ObjectMeta is metadata about an object that is common across all API objects the dashboard
+// exposes. It mirrors the subset of k8s.io/kubernetes/pkg/api.ObjectMeta that the frontend cares
+// about.
+type ObjectMeta struct {
+	Name              string            `json:"name,omitempty"`
+	Namespace         string            `json:"namespace,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Annotations       map[string]string `json:"annotations,omitempty"`
+	CreationTimestamp unversioned.Time  `json:"creationTimestamp,omitempty"`
+	UID               string            `json:"uid,omitempty"`
+}
+
+// NewObjectMeta converts the k8s.io/kubernetes/pkg/api.ObjectMeta of a resource into the
+// dashboard's ObjectMeta representation.
+func NewObjectMeta(k8SObjectMeta api.ObjectMeta) ObjectMeta {
+	return ObjectMeta{
+		Name:              k8SObjectMeta.Name,
+		Namespace:         k8SObjectMeta.Namespace,
+		Labels:            k8SObjectMeta.Labels,
+		Annotations:       k8SObjectMeta.Annotations,
+		CreationTimestamp: k8SObjectMeta.CreationTimestamp,
+		UID:               string(k8SObjectMeta.UID),
+	}
+}
+
+// ListMeta describes list of objects, e.g. holds information about pagination options set for the
+// list.
+type ListMeta struct {
+	TotalItems int `json:"totalItems"`
+}
+
+// TypeMeta describes the kind of an API object so that the frontend can pick the right view/icon
+// for it without having to inspect the rest of the object.
+type TypeMeta struct {
+	Kind ResourceKind `json:"kind,omitempty"`
+}
+
+// NewTypeMeta creates TypeMeta for the given resource kind.
+func NewTypeMeta(kind ResourceKind) TypeMeta {
+	return TypeMeta{Kind: kind}
+}
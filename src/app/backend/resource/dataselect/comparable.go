@@ -0,0 +1,76 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+import (
+	"strings"
+	"time"
+)
+
+// StdComparableString is a string that implements ComparableValue.
+type StdComparableString string
+
+// Compare implements ComparableValue.
+func (s StdComparableString) Compare(other ComparableValue) int {
+	return strings.Compare(string(s), string(other.(StdComparableString)))
+}
+
+// Contains implements ComparableValue. It matches if other is a substring of s.
+func (s StdComparableString) Contains(other ComparableValue) bool {
+	return strings.Contains(string(s), string(other.(StdComparableString)))
+}
+
+// StdComparableInt is an int64 that implements ComparableValue.
+type StdComparableInt int64
+
+// Compare implements ComparableValue.
+func (i StdComparableInt) Compare(other ComparableValue) int {
+	o := other.(StdComparableInt)
+	switch {
+	case i < o:
+		return -1
+	case i > o:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Contains implements ComparableValue. Ints only match on equality.
+func (i StdComparableInt) Contains(other ComparableValue) bool {
+	return i.Compare(other) == 0
+}
+
+// StdComparableTime is a time.Time that implements ComparableValue.
+type StdComparableTime time.Time
+
+// Compare implements ComparableValue.
+func (t StdComparableTime) Compare(other ComparableValue) int {
+	o := time.Time(other.(StdComparableTime))
+	self := time.Time(t)
+	switch {
+	case self.Before(o):
+		return -1
+	case self.After(o):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Contains implements ComparableValue. Times only match on equality.
+func (t StdComparableTime) Contains(other ComparableValue) bool {
+	return t.Compare(other) == 0
+}
@@ -0,0 +1,40 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+// PropertyName is the name of a property of a GenericDataCell that can be sorted or filtered on,
+// e.g. "name" or "creationTimestamp".
+type PropertyName string
+
+// ComparableValue is a value of a resource's property that knows how to compare and match itself
+// against another value of the same underlying type. Every sortable/filterable property must be
+// expressed as a ComparableValue.
+type ComparableValue interface {
+	// Compare returns 1 if self is greater than other, 0 if they're equal and -1 otherwise.
+	Compare(other ComparableValue) int
+	// Contains returns true if self contains other, e.g. as a substring or prefix match.
+	Contains(other ComparableValue) bool
+}
+
+// GenericDataCell is the interface that every resource's data select wrapper has to implement so
+// that it can be sorted, filtered and paginated by the generic dataselect pipeline.
+type GenericDataCell interface {
+	// GetProperty returns the value of the given property of this data cell. Returns nil if this
+	// cell does not support sorting/filtering by the given property.
+	GetProperty(PropertyName) ComparableValue
+}
+
+// GenericDataList is a list of GenericDataCell than can be sorted using the standard sort package.
+type GenericDataList []GenericDataCell
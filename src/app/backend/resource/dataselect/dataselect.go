@@ -0,0 +1,106 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+import "sort"
+
+// DataSelector drives the generic filter -> sort -> paginate pipeline over a list of
+// GenericDataCell according to a DataSelectQuery.
+type DataSelector struct {
+	GenericDataList []GenericDataCell
+	DataSelectQuery *DataSelectQuery
+}
+
+// Len implements sort.Interface.
+func (s DataSelector) Len() int { return len(s.GenericDataList) }
+
+// Swap implements sort.Interface.
+func (s DataSelector) Swap(i, j int) {
+	s.GenericDataList[i], s.GenericDataList[j] = s.GenericDataList[j], s.GenericDataList[i]
+}
+
+// Less implements sort.Interface. Falls through SortByList in order, using the first property
+// that doesn't compare equal between the two cells.
+func (s DataSelector) Less(i, j int) bool {
+	for _, sortBy := range s.DataSelectQuery.SortQuery.SortByList {
+		a := s.GenericDataList[i].GetProperty(sortBy.Property)
+		b := s.GenericDataList[j].GetProperty(sortBy.Property)
+		if a == nil || b == nil {
+			continue
+		}
+
+		cmp := a.Compare(b)
+		if cmp == 0 {
+			continue
+		}
+		return (cmp == -1) == sortBy.Ascending
+	}
+	return false
+}
+
+// Filter removes every cell that doesn't match the DataSelectQuery's FilterQuery, in place.
+func (s *DataSelector) Filter() *DataSelector {
+	filterQuery := s.DataSelectQuery.FilterQuery
+	if filterQuery == nil || len(filterQuery.FilterByList) == 0 {
+		return s
+	}
+
+	filtered := make([]GenericDataCell, 0, len(s.GenericDataList))
+	for _, cell := range s.GenericDataList {
+		if filterQuery.Matches(cell) {
+			filtered = append(filtered, cell)
+		}
+	}
+	s.GenericDataList = filtered
+	return s
+}
+
+// Sort sorts the data according to the DataSelectQuery's SortQuery, in place.
+func (s *DataSelector) Sort() *DataSelector {
+	sort.Sort(*s)
+	return s
+}
+
+// Paginate slices the data down to the page requested by the DataSelectQuery's PaginationQuery.
+func (s *DataSelector) Paginate() *DataSelector {
+	pQuery := s.DataSelectQuery.PaginationQuery
+	startIndex, endIndex := pQuery.GetPaginationSettings(len(s.GenericDataList))
+	s.GenericDataList = s.GenericDataList[startIndex:endIndex]
+	return s
+}
+
+// GenericDataSelect takes a list of GenericDataCell and a DataSelectQuery and returns just the
+// selected (filtered, sorted and paginated) data.
+func GenericDataSelect(dataList []GenericDataCell, dsQuery *DataSelectQuery) []GenericDataCell {
+	selected, _ := GenericDataSelectWithFilter(dataList, dsQuery)
+	return selected
+}
+
+// GenericDataSelectWithFilter is like GenericDataSelect, but also returns the number of items
+// that matched the FilterQuery before pagination was applied - the correct "total items" count
+// for a filtered, paginated list.
+func GenericDataSelectWithFilter(dataList []GenericDataCell, dsQuery *DataSelectQuery) (
+	[]GenericDataCell, int) {
+	selector := &DataSelector{
+		GenericDataList: dataList,
+		DataSelectQuery: dsQuery,
+	}
+
+	selector = selector.Filter()
+	filteredTotal := len(selector.GenericDataList)
+	selector = selector.Sort()
+	selector = selector.Paginate()
+	return selector.GenericDataList, filteredTotal
+}
@@ -17,6 +17,7 @@ package dataselect
 import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/metric"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 )
 
 // Options for GenericDataSelect which takes []GenericDataCell and returns selected data.
@@ -25,8 +26,8 @@ import (
 type DataSelectQuery struct {
 	PaginationQuery *PaginationQuery
 	SortQuery       *SortQuery
-	//	Filter     *FilterQuery
-	MetricQuery *MetricQuery
+	FilterQuery     *FilterQuery
+	MetricQuery     *MetricQuery
 }
 
 var NoMetrics = NewMetricQuery(nil, nil)
@@ -40,20 +41,55 @@ var StandardMetrics = NewMetricQuery([]string{common.CpuUsage, common.MemoryUsag
 // It accepts list of metrics to be downloaded and a list of aggregations that should be performed for each metric.
 // Query has this format  metrics=metric1,metric2,...&aggregations=aggregation1,aggregation2,...
 type MetricQuery struct {
+	// Provider is the backend the metrics are read from - Heapster by default, or custom.metrics.k8s.io /
+	// external.metrics.k8s.io for the metric sources autoscaling/v2 HPAs can drive on.
+	Provider metric.Provider
 	// Metrics to download, all available metric names can be found here:
 	// https://github.com/kubernetes/heapster/blob/master/docs/storage-schema.md
 	MetricNames []string
 	// Aggregations to be performed for each metric. Check available aggregations in aggregation.go.
 	// If empty, default aggregation will be used (sum).
 	Aggregations metric.AggregationNames
+	// MetricSelector scopes a custom Pods metric to the set of pods it is averaged over. Unused
+	// by the Heapster provider.
+	MetricSelector *unversioned.LabelSelector
+	// TargetRef additionally scopes a custom metric to a single named object, e.g. the Ingress an
+	// Object metric is collected from. Nil for Pods/External metrics.
+	TargetRef *metric.ObjectReference
 }
 
-// NewMetricQuery returns a metric query from provided settings.
-func NewMetricQuery(metricNames []string, aggregations metric.AggregationNames) *MetricQuery {
-	return &MetricQuery{
+// MetricQueryOption configures optional MetricQuery fields that most callers don't need, keeping
+// NewMetricQuery's required signature unchanged for existing Heapster-only callers.
+type MetricQueryOption func(*MetricQuery)
+
+// WithProvider overrides the metric provider. Defaults to metric.DefaultProvider (Heapster).
+func WithProvider(provider metric.Provider) MetricQueryOption {
+	return func(q *MetricQuery) { q.Provider = provider }
+}
+
+// WithMetricSelector scopes a custom Pods metric to the pods matching selector.
+func WithMetricSelector(selector *unversioned.LabelSelector) MetricQueryOption {
+	return func(q *MetricQuery) { q.MetricSelector = selector }
+}
+
+// WithTargetRef scopes a custom metric to a single named object.
+func WithTargetRef(targetRef *metric.ObjectReference) MetricQueryOption {
+	return func(q *MetricQuery) { q.TargetRef = targetRef }
+}
+
+// NewMetricQuery returns a metric query from provided settings. Defaults to the Heapster
+// provider; pass options such as WithProvider to target custom/external metrics instead.
+func NewMetricQuery(metricNames []string, aggregations metric.AggregationNames,
+	options ...MetricQueryOption) *MetricQuery {
+	query := &MetricQuery{
+		Provider:     metric.DefaultProvider,
 		MetricNames:  metricNames,
 		Aggregations: aggregations,
 	}
+	for _, option := range options {
+		option(query)
+	}
+	return query
 }
 
 // SortQuery holds options for sort functionality of data select.
@@ -73,22 +109,24 @@ var NoSort = &SortQuery{
 }
 
 // NoDataSelect is an option for no data select (same data will be returned).
-var NoDataSelect = NewDataSelectQuery(NoPagination, NoSort, NoMetrics)
+var NoDataSelect = NewDataSelectQuery(NoPagination, NoSort, NoFilter, NoMetrics)
 
 // StdMetricsDataSelect does not perform any data select, just downloads standard metrics.
-var StdMetricsDataSelect = NewDataSelectQuery(NoPagination, NoSort, StandardMetrics)
+var StdMetricsDataSelect = NewDataSelectQuery(NoPagination, NoSort, NoFilter, StandardMetrics)
 
-// DefaultDataSelect downloads first 10 items from page 1 with no sort and no metrics.
-var DefaultDataSelect = NewDataSelectQuery(DefaultPagination, NoSort, NoMetrics)
+// DefaultDataSelect downloads first 10 items from page 1 with no sort, no filter and no metrics.
+var DefaultDataSelect = NewDataSelectQuery(DefaultPagination, NoSort, NoFilter, NoMetrics)
 
-// DefaultDataSelectWithMetrics downloads first 10 items from page 1 with no sort. Also downloads and includes standard metrics.
-var DefaultDataSelectWithMetrics = NewDataSelectQuery(DefaultPagination, NoSort, StandardMetrics)
+// DefaultDataSelectWithMetrics downloads first 10 items from page 1 with no sort, no filter. Also downloads and includes standard metrics.
+var DefaultDataSelectWithMetrics = NewDataSelectQuery(DefaultPagination, NoSort, NoFilter, StandardMetrics)
 
 // NewDataSelectQuery creates DataSelectQuery object from simpler data select queries.
-func NewDataSelectQuery(paginationQuery *PaginationQuery, sortQuery *SortQuery, graphQuery *MetricQuery) *DataSelectQuery {
+func NewDataSelectQuery(paginationQuery *PaginationQuery, sortQuery *SortQuery, filterQuery *FilterQuery,
+	graphQuery *MetricQuery) *DataSelectQuery {
 	return &DataSelectQuery{
 		PaginationQuery: paginationQuery,
 		SortQuery:       sortQuery,
+		FilterQuery:     filterQuery,
 		MetricQuery:     graphQuery,
 	}
 }
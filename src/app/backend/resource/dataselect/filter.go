@@ -0,0 +1,158 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterOp is a comparison operator a FilterBy can apply to a property.
+type FilterOp string
+
+// List of all filter operators supported by FilterQuery.
+const (
+	FilterOpEq       FilterOp = "eq"
+	FilterOpContains FilterOp = "contains"
+	FilterOpPrefix   FilterOp = "prefix"
+	FilterOpGt       FilterOp = "gt"
+	FilterOpLt       FilterOp = "lt"
+	FilterOpIn       FilterOp = "in"
+)
+
+// FilterBy holds a single filter predicate: the property to filter on, the comparison to apply
+// and the value to compare against. For FilterOpIn, Value is a list of alternatives separated by
+// "|" (not "," - the raw filterBy query parameter is already comma-split into property/op/value
+// triples, so a literal "," can never reach Value) and the predicate matches if any one of them
+// does.
+type FilterBy struct {
+	Property PropertyName
+	Op       FilterOp
+	Value    string
+}
+
+// FilterQuery holds the list of filter predicates that should be applied to a data list. All
+// predicates in FilterByList must match (AND semantics) for a cell to be kept.
+type FilterQuery struct {
+	FilterByList []FilterBy
+}
+
+// NoFilter is an option for no filtering - the whole list is kept.
+var NoFilter = &FilterQuery{FilterByList: []FilterBy{}}
+
+// NewFilterQuery takes a raw, flattened filter options list and returns a FilterQuery. For
+// example ["name", "contains", "nginx", "namespace", "eq", "prod"] - built by splitting the
+// filterBy=name,contains,nginx;namespace,eq,prod query parameter on ";" and then "," - means that
+// only items whose name contains "nginx" and whose namespace equals "prod" are kept. An "in"
+// predicate's value uses "|" to separate its alternatives instead, e.g.
+// filterBy=status,in,Running|Pending, since "," is already spoken for by the triple split above.
+func NewFilterQuery(filterByListRaw []string) *FilterQuery {
+	if filterByListRaw == nil || len(filterByListRaw)%3 != 0 {
+		// Empty filter list or invalid (not a multiple of 3) length.
+		return NoFilter
+	}
+
+	filterByList := []FilterBy{}
+	for i := 0; i+2 < len(filterByListRaw); i += 3 {
+		property := filterByListRaw[i]
+		op := FilterOp(filterByListRaw[i+1])
+		value := filterByListRaw[i+2]
+
+		if !op.isValid() {
+			// Invalid filter operator.
+			return NoFilter
+		}
+
+		filterByList = append(filterByList, FilterBy{
+			Property: PropertyName(property),
+			Op:       op,
+			Value:    value,
+		})
+	}
+	return &FilterQuery{FilterByList: filterByList}
+}
+
+func (op FilterOp) isValid() bool {
+	switch op {
+	case FilterOpEq, FilterOpContains, FilterOpPrefix, FilterOpGt, FilterOpLt, FilterOpIn:
+		return true
+	default:
+		return false
+	}
+}
+
+// Matches returns true if cell satisfies every predicate in the FilterQuery.
+func (f *FilterQuery) Matches(cell GenericDataCell) bool {
+	for _, filterBy := range f.FilterByList {
+		property := cell.GetProperty(filterBy.Property)
+		if property == nil || !filterBy.matches(property) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f FilterBy) matches(property ComparableValue) bool {
+	if f.Op == FilterOpIn {
+		for _, value := range strings.Split(f.Value, "|") {
+			if (FilterBy{Property: f.Property, Op: FilterOpEq, Value: value}).matches(property) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch p := property.(type) {
+	case StdComparableInt:
+		value, err := strconv.ParseInt(f.Value, 10, 64)
+		if err != nil {
+			return false
+		}
+		return matchOrdered(p, StdComparableInt(value), f.Op)
+	case StdComparableTime:
+		value, err := time.Parse(time.RFC3339, f.Value)
+		if err != nil {
+			return false
+		}
+		return matchOrdered(p, StdComparableTime(value), f.Op)
+	default:
+		value := StdComparableString(f.Value)
+		return matchOrdered(p, value, f.Op)
+	}
+}
+
+// matchOrdered applies an Eq/Contains/Prefix/Gt/Lt operator to a property/value pair of the same
+// underlying type. Eq/Contains/Gt/Lt work on any ComparableValue; Prefix only matches strings.
+func matchOrdered(property, value ComparableValue, op FilterOp) bool {
+	switch op {
+	case FilterOpEq:
+		return property.Compare(value) == 0
+	case FilterOpContains:
+		return property.Contains(value)
+	case FilterOpPrefix:
+		s, ok := property.(StdComparableString)
+		if !ok {
+			return false
+		}
+		return strings.HasPrefix(string(s), string(value.(StdComparableString)))
+	case FilterOpGt:
+		return property.Compare(value) > 0
+	case FilterOpLt:
+		return property.Compare(value) < 0
+	default:
+		return false
+	}
+}
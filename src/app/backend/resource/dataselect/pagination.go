@@ -0,0 +1,59 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+// PaginationQuery holds pagination parameters necessary to return paginated data from the list
+// of items it is applied to.
+type PaginationQuery struct {
+	ItemsPerPage int
+	Page         int
+}
+
+// NewPaginationQuery returns PaginationQuery with provided parameters.
+func NewPaginationQuery(itemsPerPage, page int) *PaginationQuery {
+	return &PaginationQuery{itemsPerPage, page}
+}
+
+// NoPagination is an option for no pagination - the whole list is returned.
+var NoPagination = NewPaginationQuery(-1, -1)
+
+// DefaultPagination returns the first 10 items from page 1.
+var DefaultPagination = NewPaginationQuery(10, 0)
+
+// isValidPagination returns true if pagination has sane item-per-page and page values.
+func (p *PaginationQuery) isValidPagination() bool {
+	return !(p.ItemsPerPage <= 0 || p.Page < 0)
+}
+
+// GetPaginationSettings returns the start and end index to be used to slice a list of the given
+// length to match the requested page. If the query is invalid (e.g. NoPagination), the whole
+// range [0, dataLength) is returned; if the requested page is out of range, the empty range
+// [0, 0) is returned.
+func (p *PaginationQuery) GetPaginationSettings(dataLength int) (startIndex int, endIndex int) {
+	if !p.isValidPagination() {
+		return 0, dataLength
+	}
+
+	startIndex = p.ItemsPerPage * p.Page
+	endIndex = startIndex + p.ItemsPerPage
+
+	if startIndex >= dataLength {
+		return 0, 0
+	}
+	if endIndex > dataLength {
+		endIndex = dataLength
+	}
+	return startIndex, endIndex
+}
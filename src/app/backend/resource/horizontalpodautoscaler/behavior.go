@@ -0,0 +1,104 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
+)
+
+// ScalingPolicy is a single rate-limiting rule for a scaling direction, e.g. "add at most 4 pods
+// every 60 seconds".
+type ScalingPolicy struct {
+	Type          autoscaling.HPAScalingPolicyType `json:"type"`
+	Value         int32                            `json:"value"`
+	PeriodSeconds int32                            `json:"periodSeconds"`
+}
+
+// ScalingRules is the set of rate-limiting rules the autoscaler applies for a single scaling
+// direction (up or down).
+type ScalingRules struct {
+	StabilizationWindowSeconds *int32                           `json:"stabilizationWindowSeconds,omitempty"`
+	SelectPolicy               *autoscaling.ScalingPolicySelect `json:"selectPolicy,omitempty"`
+	Policies                   []ScalingPolicy                  `json:"policies,omitempty"`
+}
+
+// Behavior is the normalized spec.behavior block, explaining the rate limits the autoscaler
+// applies when scaling up or down.
+type Behavior struct {
+	ScaleUp   *ScalingRules `json:"scaleUp,omitempty"`
+	ScaleDown *ScalingRules `json:"scaleDown,omitempty"`
+}
+
+// Condition is a single status condition reported by the HPA controller, e.g.
+// "ScalingLimited=True, Reason=TooFewReplicas" explaining why the autoscaler isn't scaling the
+// way a user might expect.
+type Condition struct {
+	Type               autoscaling.HorizontalPodAutoscalerConditionType `json:"type"`
+	Status             api.ConditionStatus                              `json:"status"`
+	LastTransitionTime unversioned.Time                                 `json:"lastTransitionTime,omitempty"`
+	Reason             string                                           `json:"reason,omitempty"`
+	Message            string                                           `json:"message,omitempty"`
+}
+
+// ToBehavior converts the spec.behavior block of a HorizontalPodAutoscaler into its normalized
+// representation. Returns nil if the autoscaler (or the cluster it came from) doesn't set one.
+func ToBehavior(behavior *autoscaling.HorizontalPodAutoscalerBehavior) *Behavior {
+	if behavior == nil {
+		return nil
+	}
+	return &Behavior{
+		ScaleUp:   toScalingRules(behavior.ScaleUp),
+		ScaleDown: toScalingRules(behavior.ScaleDown),
+	}
+}
+
+func toScalingRules(rules *autoscaling.HPAScalingRules) *ScalingRules {
+	if rules == nil {
+		return nil
+	}
+
+	policies := make([]ScalingPolicy, 0, len(rules.Policies))
+	for _, policy := range rules.Policies {
+		policies = append(policies, ScalingPolicy{
+			Type:          policy.Type,
+			Value:         policy.Value,
+			PeriodSeconds: policy.PeriodSeconds,
+		})
+	}
+
+	return &ScalingRules{
+		StabilizationWindowSeconds: rules.StabilizationWindowSeconds,
+		SelectPolicy:               rules.SelectPolicy,
+		Policies:                   policies,
+	}
+}
+
+// ToConditions converts the status.conditions of a HorizontalPodAutoscaler into their normalized
+// representation.
+func ToConditions(conditions []autoscaling.HorizontalPodAutoscalerCondition) []Condition {
+	result := make([]Condition, 0, len(conditions))
+	for _, condition := range conditions {
+		result = append(result, Condition{
+			Type:               condition.Type,
+			Status:             condition.Status,
+			LastTransitionTime: condition.LastTransitionTime,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+		})
+	}
+	return result
+}
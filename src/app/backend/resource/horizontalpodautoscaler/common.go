@@ -0,0 +1,216 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
+)
+
+// ScaleTargetRef is the object that an autoscaler is scaling, e.g. a Deployment or
+// ReplicationController.
+type ScaleTargetRef struct {
+	// Kind of the referent, e.g. "ReplicationController".
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the referent.
+	Name string `json:"name,omitempty"`
+}
+
+// MetricTarget is the normalized representation of what a metric's target value is - only the
+// field matching Type is set.
+type MetricTarget struct {
+	Type               autoscaling.MetricTargetType `json:"type,omitempty"`
+	Value              *resource.Quantity           `json:"value,omitempty"`
+	AverageValue       *resource.Quantity           `json:"averageValue,omitempty"`
+	AverageUtilization *int32                       `json:"averageUtilization,omitempty"`
+}
+
+// CurrentMetricStatus is the normalized representation of the value a metric last reported -
+// only the field matching the metric's Target.Type is set.
+type CurrentMetricStatus struct {
+	Value              *resource.Quantity `json:"value,omitempty"`
+	AverageValue       *resource.Quantity `json:"averageValue,omitempty"`
+	AverageUtilization *int32             `json:"averageUtilization,omitempty"`
+}
+
+// Metric is a single, normalized autoscaling/v2 metric entry - its source, the target the
+// autoscaler is driving towards and, if known, the value the controller last observed. This lets
+// the UI render mixed Resource/ContainerResource/Pods/Object/External metric rows instead of a
+// hardcoded CPU percentage.
+type Metric struct {
+	// Type is the source of this metric, e.g. Resource, ContainerResource, Pods, Object, External.
+	Type autoscaling.MetricSourceType `json:"type"`
+
+	// Name is the metric's name, e.g. "cpu" for a Resource metric or the custom metric name for
+	// Pods/Object/External metrics.
+	Name string `json:"name"`
+
+	// Container is set only for ContainerResource metrics and names the container the metric is
+	// collected from.
+	Container string `json:"container,omitempty"`
+
+	Target  MetricTarget         `json:"target"`
+	Current *CurrentMetricStatus `json:"current,omitempty"`
+}
+
+// ToMetrics converts the spec/status of a HorizontalPodAutoscaler into the normalized Metric list
+// used throughout the dashboard. Clusters that only understand autoscaling/v1 never populate
+// Spec.Metrics - in that case a single Resource/cpu entry is synthesized from the legacy
+// TargetCPUUtilizationPercentage field so v1 HPAs keep rendering correctly.
+func ToMetrics(spec autoscaling.HorizontalPodAutoscalerSpec, status autoscaling.HorizontalPodAutoscalerStatus) []Metric {
+	if len(spec.Metrics) == 0 {
+		return toMetricsFromV1(spec, status)
+	}
+
+	current := indexCurrentMetrics(status.CurrentMetrics)
+	metrics := make([]Metric, 0, len(spec.Metrics))
+	for _, metricSpec := range spec.Metrics {
+		metric, ok := toMetric(metricSpec)
+		if !ok {
+			continue
+		}
+		if currentStatus, ok := current[metricKey(metric.Type, metric.Name, metric.Container)]; ok {
+			metric.Current = &currentStatus
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+func toMetric(spec autoscaling.MetricSpec) (Metric, bool) {
+	switch spec.Type {
+	case autoscaling.ResourceMetricSourceType:
+		if spec.Resource == nil {
+			return Metric{}, false
+		}
+		return Metric{
+			Type:   spec.Type,
+			Name:   string(spec.Resource.Name),
+			Target: toMetricTarget(spec.Resource.Target),
+		}, true
+	case autoscaling.ContainerResourceMetricSourceType:
+		if spec.ContainerResource == nil {
+			return Metric{}, false
+		}
+		return Metric{
+			Type:      spec.Type,
+			Name:      string(spec.ContainerResource.Name),
+			Container: spec.ContainerResource.Container,
+			Target:    toMetricTarget(spec.ContainerResource.Target),
+		}, true
+	case autoscaling.PodsMetricSourceType:
+		if spec.Pods == nil {
+			return Metric{}, false
+		}
+		return Metric{
+			Type:   spec.Type,
+			Name:   spec.Pods.Metric.Name,
+			Target: toMetricTarget(spec.Pods.Target),
+		}, true
+	case autoscaling.ObjectMetricSourceType:
+		if spec.Object == nil {
+			return Metric{}, false
+		}
+		return Metric{
+			Type:   spec.Type,
+			Name:   spec.Object.Metric.Name,
+			Target: toMetricTarget(spec.Object.Target),
+		}, true
+	case autoscaling.ExternalMetricSourceType:
+		if spec.External == nil {
+			return Metric{}, false
+		}
+		return Metric{
+			Type:   spec.Type,
+			Name:   spec.External.Metric.Name,
+			Target: toMetricTarget(spec.External.Target),
+		}, true
+	default:
+		return Metric{}, false
+	}
+}
+
+func toMetricTarget(target autoscaling.MetricTarget) MetricTarget {
+	return MetricTarget{
+		Type:               target.Type,
+		Value:              target.Value,
+		AverageValue:       target.AverageValue,
+		AverageUtilization: target.AverageUtilization,
+	}
+}
+
+func toMetricsFromV1(spec autoscaling.HorizontalPodAutoscalerSpec, status autoscaling.HorizontalPodAutoscalerStatus) []Metric {
+	if spec.TargetCPUUtilizationPercentage == nil {
+		return []Metric{}
+	}
+
+	metric := Metric{
+		Type: autoscaling.ResourceMetricSourceType,
+		Name: "cpu",
+		Target: MetricTarget{
+			Type:               autoscaling.UtilizationMetricType,
+			AverageUtilization: spec.TargetCPUUtilizationPercentage,
+		},
+	}
+	if status.CurrentCPUUtilizationPercentage != nil {
+		metric.Current = &CurrentMetricStatus{AverageUtilization: status.CurrentCPUUtilizationPercentage}
+	}
+	return []Metric{metric}
+}
+
+// indexCurrentMetrics flattens the status' per-source metric statuses into a map keyed by
+// (type, name, container) so ToMetrics can attach the current value to its matching spec entry.
+func indexCurrentMetrics(statuses []autoscaling.MetricStatus) map[string]CurrentMetricStatus {
+	current := make(map[string]CurrentMetricStatus, len(statuses))
+	for _, status := range statuses {
+		switch status.Type {
+		case autoscaling.ResourceMetricSourceType:
+			if status.Resource != nil {
+				current[metricKey(status.Type, string(status.Resource.Name), "")] = toCurrentMetricStatus(status.Resource.Current)
+			}
+		case autoscaling.ContainerResourceMetricSourceType:
+			if status.ContainerResource != nil {
+				current[metricKey(status.Type, string(status.ContainerResource.Name), status.ContainerResource.Container)] =
+					toCurrentMetricStatus(status.ContainerResource.Current)
+			}
+		case autoscaling.PodsMetricSourceType:
+			if status.Pods != nil {
+				current[metricKey(status.Type, status.Pods.Metric.Name, "")] = toCurrentMetricStatus(status.Pods.Current)
+			}
+		case autoscaling.ObjectMetricSourceType:
+			if status.Object != nil {
+				current[metricKey(status.Type, status.Object.Metric.Name, "")] = toCurrentMetricStatus(status.Object.Current)
+			}
+		case autoscaling.ExternalMetricSourceType:
+			if status.External != nil {
+				current[metricKey(status.Type, status.External.Metric.Name, "")] = toCurrentMetricStatus(status.External.Current)
+			}
+		}
+	}
+	return current
+}
+
+func toCurrentMetricStatus(value autoscaling.MetricValueStatus) CurrentMetricStatus {
+	return CurrentMetricStatus{
+		Value:              value.Value,
+		AverageValue:       value.AverageValue,
+		AverageUtilization: value.AverageUtilization,
+	}
+}
+
+func metricKey(metricType autoscaling.MetricSourceType, name, container string) string {
+	return string(metricType) + "/" + name + "/" + container
+}
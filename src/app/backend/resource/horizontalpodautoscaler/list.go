@@ -0,0 +1,85 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"log"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+)
+
+// HorizontalPodAutoscalerList contains a list of horizontal pod autoscalers in the cluster.
+type HorizontalPodAutoscalerList struct {
+	ListMeta common.ListMeta           `json:"listMeta"`
+	Items    []HorizontalPodAutoscaler `json:"items"`
+}
+
+// HorizontalPodAutoscaler is a single entry in HorizontalPodAutoscalerList - a summary view used
+// by the list page, as opposed to the full HorizontalPodAutoscalerDetail.
+type HorizontalPodAutoscaler struct {
+	ObjectMeta common.ObjectMeta `json:"objectMeta"`
+	TypeMeta   common.TypeMeta   `json:"typeMeta"`
+
+	ScaleTargetRef ScaleTargetRef `json:"scaleTargetRef"`
+
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	MaxReplicas int32  `json:"maxReplicas"`
+
+	CurrentReplicas int32 `json:"currentReplicas"`
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	Metrics []Metric `json:"metrics"`
+}
+
+// GetHorizontalPodAutoscalerList returns a list of all horizontal pod autoscalers in the given
+// namespace.
+func GetHorizontalPodAutoscalerList(client *client.Client, namespace string) (*HorizontalPodAutoscalerList, error) {
+	log.Printf("Getting list of all horizontal pod autoscalers in the %s namespace", namespace)
+
+	list, err := client.Autoscaling().HorizontalPodAutoscalers(namespace).List(api.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toHorizontalPodAutoscalerList(list.Items), nil
+}
+
+func toHorizontalPodAutoscalerList(hpas []autoscaling.HorizontalPodAutoscaler) *HorizontalPodAutoscalerList {
+	list := &HorizontalPodAutoscalerList{
+		Items:    make([]HorizontalPodAutoscaler, 0, len(hpas)),
+		ListMeta: common.ListMeta{TotalItems: len(hpas)},
+	}
+
+	for _, hpa := range hpas {
+		list.Items = append(list.Items, HorizontalPodAutoscaler{
+			ObjectMeta: common.NewObjectMeta(hpa.ObjectMeta),
+			TypeMeta:   common.NewTypeMeta(common.ResourceKindHorizontalPodAutoscaler),
+			ScaleTargetRef: ScaleTargetRef{
+				Kind: hpa.Spec.ScaleTargetRef.Kind,
+				Name: hpa.Spec.ScaleTargetRef.Name,
+			},
+			MinReplicas:     hpa.Spec.MinReplicas,
+			MaxReplicas:     hpa.Spec.MaxReplicas,
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			DesiredReplicas: hpa.Status.DesiredReplicas,
+			Metrics:         ToMetrics(hpa.Spec, hpa.Status),
+		})
+	}
+
+	return list
+}
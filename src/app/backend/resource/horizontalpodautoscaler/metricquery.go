@@ -0,0 +1,87 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/metric"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
+)
+
+// MetricQueryFor finds the spec.Metrics entry m was normalized from and builds the
+// dataselect.MetricQuery needed to fetch its live value. Returns false if m has no matching entry,
+// e.g. because m was synthesized from the legacy autoscaling/v1 CPU target.
+func MetricQueryFor(spec autoscaling.HorizontalPodAutoscalerSpec, m Metric) (*dataselect.MetricQuery, bool) {
+	for _, metricSpec := range spec.Metrics {
+		candidate, ok := toMetric(metricSpec)
+		if !ok {
+			continue
+		}
+		if candidate.Type == m.Type && candidate.Name == m.Name && candidate.Container == m.Container {
+			return ToMetricQuery(metricSpec)
+		}
+	}
+	return nil, false
+}
+
+// ToMetricQuery builds the dataselect.MetricQuery needed to fetch the live value of a single
+// autoscaling/v2 metric source, picking the provider and scoping (label selector / target object)
+// the metric's type implies. Resource and ContainerResource metrics come from Heapster just like
+// the rest of the dashboard's CPU/memory usage; Pods and Object metrics come from
+// custom.metrics.k8s.io; External metrics come from external.metrics.k8s.io. Returns false for
+// metric sources the dashboard doesn't know how to query (e.g. a malformed spec).
+func ToMetricQuery(spec autoscaling.MetricSpec) (*dataselect.MetricQuery, bool) {
+	switch spec.Type {
+	case autoscaling.ResourceMetricSourceType:
+		if spec.Resource == nil {
+			return nil, false
+		}
+		return dataselect.NewMetricQuery([]string{string(spec.Resource.Name)}, metric.OnlySumAggregation,
+			dataselect.WithProvider(metric.HeapsterProvider)), true
+	case autoscaling.ContainerResourceMetricSourceType:
+		if spec.ContainerResource == nil {
+			return nil, false
+		}
+		return dataselect.NewMetricQuery([]string{string(spec.ContainerResource.Name)}, metric.OnlySumAggregation,
+			dataselect.WithProvider(metric.HeapsterProvider)), true
+	case autoscaling.PodsMetricSourceType:
+		if spec.Pods == nil {
+			return nil, false
+		}
+		return dataselect.NewMetricQuery([]string{spec.Pods.Metric.Name}, metric.OnlySumAggregation,
+			dataselect.WithProvider(metric.CustomProvider),
+			dataselect.WithMetricSelector(spec.Pods.Metric.Selector)), true
+	case autoscaling.ObjectMetricSourceType:
+		if spec.Object == nil {
+			return nil, false
+		}
+		return dataselect.NewMetricQuery([]string{spec.Object.Metric.Name}, metric.OnlySumAggregation,
+			dataselect.WithProvider(metric.CustomProvider),
+			dataselect.WithMetricSelector(spec.Object.Metric.Selector),
+			dataselect.WithTargetRef(&metric.ObjectReference{
+				Kind: spec.Object.DescribedObject.Kind,
+				Name: spec.Object.DescribedObject.Name,
+			})), true
+	case autoscaling.ExternalMetricSourceType:
+		if spec.External == nil {
+			return nil, false
+		}
+		return dataselect.NewMetricQuery([]string{spec.External.Metric.Name}, metric.OnlySumAggregation,
+			dataselect.WithProvider(metric.ExternalProvider),
+			dataselect.WithMetricSelector(spec.External.Metric.Selector)), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package horizontalpodautoscalerdetail contains APIs to retrieve the full detail of a single
+// HorizontalPodAutoscaler, including its autoscaling/v2 multi-metric configuration.
+package horizontalpodautoscalerdetail
+
+import (
+	"log"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/horizontalpodautoscaler"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/metric"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+)
+
+// HorizontalPodAutoscalerDetail is the full representation of a HorizontalPodAutoscaler returned
+// by GetHorizontalPodAutoscalerDetail.
+type HorizontalPodAutoscalerDetail struct {
+	ObjectMeta common.ObjectMeta `json:"objectMeta"`
+	TypeMeta   common.TypeMeta   `json:"typeMeta"`
+
+	ScaleTargetRef horizontalpodautoscaler.ScaleTargetRef `json:"scaleTargetRef"`
+
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	MaxReplicas int32  `json:"maxReplicas"`
+
+	CurrentReplicas int32 `json:"currentReplicas"`
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// Metrics is the normalized list of autoscaling/v2 metric sources the autoscaler is driving
+	// on, each with its target and - if the controller has reported one - current value. v1-only
+	// clusters are represented as a single Resource/cpu entry.
+	Metrics []horizontalpodautoscaler.Metric `json:"metrics"`
+
+	// Behavior holds the rate-limiting rules the autoscaler applies when scaling up or down. Nil
+	// if the autoscaler (or the cluster it came from) doesn't set spec.behavior.
+	Behavior *horizontalpodautoscaler.Behavior `json:"behavior,omitempty"`
+
+	// Conditions explain why the autoscaler is or isn't scaling, e.g.
+	// "ScalingLimited=True, Reason=TooFewReplicas".
+	Conditions []horizontalpodautoscaler.Condition `json:"conditions"`
+
+	LastScaleTime      *unversioned.Time `json:"lastScaleTime,omitempty"`
+	ObservedGeneration *int64            `json:"observedGeneration,omitempty"`
+}
+
+// GetHorizontalPodAutoscalerDetail returns detailed information about the given
+// HorizontalPodAutoscaler in the given namespace. The same internal HorizontalPodAutoscaler type
+// carries both the legacy v1 CPU target and the v2 Metrics array, so there's no separate v1/v2
+// client path to pick between - horizontalpodautoscaler.ToMetrics falls back to the CPU target
+// itself whenever Spec.Metrics is empty.
+func GetHorizontalPodAutoscalerDetail(client *client.Client, namespace string, name string) (
+	*HorizontalPodAutoscalerDetail, error) {
+	log.Printf("Getting details of %s horizontal pod autoscaler in %s namespace", name, namespace)
+
+	rawObject, err := client.Autoscaling().HorizontalPodAutoscalers(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := getHorizontalPodAutoscalerDetail(rawObject)
+	fillLiveMetricValues(rawObject.Spec, namespace, detail.Metrics)
+	return detail, nil
+}
+
+// fillLiveMetricValues populates Current on any metric the HPA controller hasn't reported a
+// status for yet (e.g. it hasn't polled since the autoscaler was created) by querying the
+// metric's provider directly, when the dashboard has a client registered for it. Errors are
+// logged and otherwise ignored - a metric the dashboard can't reach is shown without a current
+// value rather than failing the whole detail request.
+func fillLiveMetricValues(spec autoscaling.HorizontalPodAutoscalerSpec, namespace string, metrics []horizontalpodautoscaler.Metric) {
+	for i, m := range metrics {
+		if m.Current != nil {
+			continue
+		}
+
+		query, ok := horizontalpodautoscaler.MetricQueryFor(spec, m)
+		if !ok {
+			continue
+		}
+
+		metricClient, ok := metric.ClientFor(query.Provider)
+		if !ok {
+			continue
+		}
+
+		value, err := metricClient.GetMetric(query.MetricNames[0], namespace, query.MetricSelector, query.TargetRef)
+		if err != nil {
+			log.Printf("Error getting live value of metric %s: %s", query.MetricNames[0], err)
+			continue
+		}
+		metrics[i].Current = &horizontalpodautoscaler.CurrentMetricStatus{
+			Value:        value.Value,
+			AverageValue: value.AverageValue,
+		}
+	}
+}
+
+func getHorizontalPodAutoscalerDetail(hpa *autoscaling.HorizontalPodAutoscaler) *HorizontalPodAutoscalerDetail {
+	return &HorizontalPodAutoscalerDetail{
+		ObjectMeta: common.NewObjectMeta(hpa.ObjectMeta),
+		TypeMeta:   common.NewTypeMeta(common.ResourceKindHorizontalPodAutoscaler),
+		ScaleTargetRef: horizontalpodautoscaler.ScaleTargetRef{
+			Kind: hpa.Spec.ScaleTargetRef.Kind,
+			Name: hpa.Spec.ScaleTargetRef.Name,
+		},
+		MinReplicas:        hpa.Spec.MinReplicas,
+		MaxReplicas:        hpa.Spec.MaxReplicas,
+		CurrentReplicas:    hpa.Status.CurrentReplicas,
+		DesiredReplicas:    hpa.Status.DesiredReplicas,
+		Metrics:            horizontalpodautoscaler.ToMetrics(hpa.Spec, hpa.Status),
+		Behavior:           horizontalpodautoscaler.ToBehavior(hpa.Spec.Behavior),
+		Conditions:         horizontalpodautoscaler.ToConditions(hpa.Status.Conditions),
+		LastScaleTime:      hpa.Status.LastScaleTime,
+		ObservedGeneration: hpa.Status.ObservedGeneration,
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metric contains logic for downloading and aggregating resource usage metrics used by
+// the dataselect package's MetricQuery.
+package metric
+
+// AggregationMode is the name of an aggregation function that can be applied to a series of
+// metric data points collected from several pods/containers/nodes, e.g. to roll up per-pod CPU
+// usage into a single number for a ReplicaSet.
+type AggregationMode string
+
+// List of all support aggregation modes.
+const (
+	SumAggregation     = AggregationMode("sum")
+	AverageAggregation = AggregationMode("average")
+	MinAggregation     = AggregationMode("min")
+	MaxAggregation     = AggregationMode("max")
+
+	DefaultAggregation = SumAggregation
+)
+
+// AggregationNames is a list of aggregation modes to apply to each downloaded metric. Used
+// in interfaces supporting aggregation.
+type AggregationNames []AggregationMode
+
+// OnlySumAggregation is an aggregation mode list with sum as the only aggregation performed.
+var OnlySumAggregation = AggregationNames{SumAggregation}
+
+// OnlyDefaultAggregation is an aggregation mode list with the default aggregation as the only
+// aggregation performed.
+var OnlyDefaultAggregation = AggregationNames{DefaultAggregation}
@@ -0,0 +1,60 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// MetricValue is a single metric reading returned by a MetricClient - the same Value/AverageValue
+// union the HPA controller itself reports in status.currentMetrics.
+type MetricValue struct {
+	Value        *resource.Quantity
+	AverageValue *resource.Quantity
+}
+
+// MetricClient fetches the current value of a single named metric from one provider.
+type MetricClient interface {
+	// GetMetric returns the current value of metricName in namespace. selector scopes a Pods
+	// metric to the set of pods it averages over, or an External metric to the series matching
+	// its labels; target additionally scopes an Object metric to a single named object and is nil
+	// for Pods and External metrics.
+	GetMetric(metricName string, namespace string, selector *unversioned.LabelSelector,
+		target *ObjectReference) (MetricValue, error)
+}
+
+// registry holds the MetricClient to use for each provider, set up once at startup by whichever
+// caller constructs the various k8s.io/metrics clientsets.
+var registry = map[Provider]MetricClient{}
+
+// RegisterMetricClient makes client the MetricClient used for the given provider.
+func RegisterMetricClient(provider Provider, client MetricClient) {
+	registry[provider] = client
+}
+
+// ClientFor returns the MetricClient registered for provider, if any.
+func ClientFor(provider Provider) (MetricClient, bool) {
+	client, ok := registry[provider]
+	return client, ok
+}
+
+// ErrNoMetricClient builds the error for a provider that has no MetricClient registered, e.g.
+// because the cluster has no custom metrics adapter installed.
+func ErrNoMetricClient(provider Provider) error {
+	return fmt.Errorf("no metric client registered for provider %q", provider)
+}
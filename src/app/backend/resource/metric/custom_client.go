@@ -0,0 +1,66 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/metrics/pkg/client/custom_metrics"
+)
+
+// customMetricClient is a MetricClient backed by custom.metrics.k8s.io, used for Pods and Object
+// HPA metrics (e.g. a Prometheus adapter's "requests-per-second").
+type customMetricClient struct {
+	client custom_metrics.CustomMetricsClient
+}
+
+// NewCustomMetricClient returns a MetricClient reading from custom.metrics.k8s.io.
+func NewCustomMetricClient(client custom_metrics.CustomMetricsClient) MetricClient {
+	return &customMetricClient{client: client}
+}
+
+func (c *customMetricClient) GetMetric(metricName string, namespace string,
+	selector *unversioned.LabelSelector, target *ObjectReference) (MetricValue, error) {
+	labelSelector, err := unversioned.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return MetricValue{}, err
+	}
+
+	if target != nil {
+		metricValue, err := c.client.NamespacedMetrics(namespace).GetForObject(
+			unversioned.GroupKind{Kind: target.Kind}, target.Name, metricName)
+		if err != nil {
+			return MetricValue{}, err
+		}
+		return MetricValue{Value: &metricValue.Value}, nil
+	}
+
+	metricValueList, err := c.client.NamespacedMetrics(namespace).GetForObjects(
+		unversioned.GroupKind{Kind: "Pod"}, labelSelector, metricName)
+	if err != nil {
+		return MetricValue{}, err
+	}
+	if len(metricValueList.Items) == 0 {
+		return MetricValue{}, fmt.Errorf("no pods matched selector %v for metric %s", selector, metricName)
+	}
+
+	var sum int64
+	for _, item := range metricValueList.Items {
+		sum += item.Value.MilliValue()
+	}
+	average := resourceQuantityFromMillis(sum / int64(len(metricValueList.Items)))
+	return MetricValue{AverageValue: &average}, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/metrics/pkg/client/external_metrics"
+)
+
+// externalMetricClient is a MetricClient backed by external.metrics.k8s.io, used for External HPA
+// metrics that aren't tied to any Kubernetes object (e.g. a cloud provider's queue depth).
+type externalMetricClient struct {
+	client external_metrics.ExternalMetricsClient
+}
+
+// NewExternalMetricClient returns a MetricClient reading from external.metrics.k8s.io.
+func NewExternalMetricClient(client external_metrics.ExternalMetricsClient) MetricClient {
+	return &externalMetricClient{client: client}
+}
+
+func (c *externalMetricClient) GetMetric(metricName string, namespace string,
+	selector *unversioned.LabelSelector, target *ObjectReference) (MetricValue, error) {
+	labelSelector, err := unversioned.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return MetricValue{}, err
+	}
+
+	metricValueList, err := c.client.NamespacedMetrics(namespace).List(metricName, labelSelector)
+	if err != nil {
+		return MetricValue{}, err
+	}
+	if len(metricValueList.Items) == 0 {
+		return MetricValue{}, fmt.Errorf("no values returned for external metric %s", metricName)
+	}
+
+	value := metricValueList.Items[0].Value
+	return MetricValue{Value: &value}, nil
+}
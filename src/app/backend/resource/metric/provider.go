@@ -0,0 +1,42 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+// Provider identifies which backend a MetricQuery's metrics should be read from.
+type Provider string
+
+// List of all metric providers a MetricQuery can target.
+const (
+	// HeapsterProvider reads the historical time-series Heapster has always exposed - the only
+	// provider that understood CPU/memory usage before custom.metrics.k8s.io existed.
+	HeapsterProvider Provider = "heapster"
+	// CustomProvider reads pod/object metrics from custom.metrics.k8s.io, e.g. the metrics a
+	// Prometheus adapter serves for a Pods or Object HPA metric.
+	CustomProvider Provider = "custom"
+	// ExternalProvider reads metrics from external.metrics.k8s.io that aren't tied to any
+	// Kubernetes object, e.g. a cloud provider's queue depth.
+	ExternalProvider Provider = "external"
+)
+
+// DefaultProvider is used by MetricQuery when no provider is set explicitly, preserving the
+// behavior every existing caller relies on.
+const DefaultProvider = HeapsterProvider
+
+// ObjectReference names the Kubernetes object a custom Object metric is collected from, e.g. the
+// Ingress a "requests-per-second" metric is reported against.
+type ObjectReference struct {
+	Kind string
+	Name string
+}
@@ -0,0 +1,55 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+// AggregationMode picks how a list of per-pod/per-node ResourceUsage values is rolled up into a
+// single cluster- or namespace-wide number.
+type AggregationMode string
+
+// List of all supported aggregation modes for top.
+const (
+	SumAggregation     AggregationMode = "sum"
+	AverageAggregation AggregationMode = "average"
+)
+
+// AggregatePodUsage rolls up the usage of every pod in items according to mode.
+func AggregatePodUsage(items []PodMetric, mode AggregationMode) ResourceUsage {
+	usages := make([]ResourceUsage, len(items))
+	for i, item := range items {
+		usages[i] = item.Usage
+	}
+	return aggregate(usages, mode)
+}
+
+// AggregateNodeUsage rolls up the usage of every node in items according to mode.
+func AggregateNodeUsage(items []NodeMetric, mode AggregationMode) ResourceUsage {
+	usages := make([]ResourceUsage, len(items))
+	for i, item := range items {
+		usages[i] = item.Usage
+	}
+	return aggregate(usages, mode)
+}
+
+func aggregate(usages []ResourceUsage, mode AggregationMode) ResourceUsage {
+	var total ResourceUsage
+	for _, usage := range usages {
+		total = total.add(usage)
+	}
+	if mode == AverageAggregation && len(usages) > 0 {
+		total.CPUMillicores /= int64(len(usages))
+		total.MemoryBytes /= int64(len(usages))
+	}
+	return total
+}
@@ -0,0 +1,48 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package top exposes point-in-time resource usage for pods and nodes, read straight from the
+// metrics.k8s.io API (the same source kubectl/karmadactl "top" uses) as opposed to the historical
+// time-series that dataselect.MetricQuery retrieves from Heapster.
+package top
+
+import "errors"
+
+// ErrMetricsNotAvailable is returned whenever the metrics.k8s.io/v1beta1 API isn't registered on
+// the cluster, e.g. because the metrics-server addon isn't installed.
+var ErrMetricsNotAvailable = errors.New("the metrics API is not available on this cluster")
+
+// ResourceUsage is a single point-in-time measurement of how much CPU and memory something is
+// using.
+type ResourceUsage struct {
+	// CPUMillicores is the CPU usage, expressed in millicores (1000m = 1 core).
+	CPUMillicores int64 `json:"cpuMillicores"`
+	// MemoryBytes is the memory usage, expressed in bytes.
+	MemoryBytes int64 `json:"memoryBytes"`
+}
+
+// ContainerUsage is the resource usage reported for a single container within a pod.
+type ContainerUsage struct {
+	Name  string        `json:"name"`
+	Usage ResourceUsage `json:"usage"`
+}
+
+// add returns the sum of u and other - used to total up per-container usage into
+// a pod/node total, and to sum usage across pods/nodes for Aggregation.
+func (u ResourceUsage) add(other ResourceUsage) ResourceUsage {
+	return ResourceUsage{
+		CPUMillicores: u.CPUMillicores + other.CPUMillicores,
+		MemoryBytes:   u.MemoryBytes + other.MemoryBytes,
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"log"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset_generated/clientset"
+)
+
+// NodeMetric is the point-in-time resource usage of a single node and - if the node reports
+// allocatable capacity - the percentage of it currently in use.
+type NodeMetric struct {
+	ObjectMeta common.ObjectMeta `json:"objectMeta"`
+	TypeMeta   common.TypeMeta   `json:"typeMeta"`
+
+	Usage ResourceUsage `json:"usage"`
+
+	CPUUtilization    *int64 `json:"cpuUtilization,omitempty"`
+	MemoryUtilization *int64 `json:"memoryUtilization,omitempty"`
+}
+
+// NodeMetricsList is a sorted, filtered and paginated list of NodeMetric.
+type NodeMetricsList struct {
+	ListMeta common.ListMeta `json:"listMeta"`
+	Items    []NodeMetric    `json:"items"`
+}
+
+// GetNodeMetricsList returns point-in-time resource usage for every node in the cluster, read
+// from the metrics.k8s.io API. Returns ErrMetricsNotAvailable if that API isn't installed.
+func GetNodeMetricsList(client *client.Client, metricsClient metricsclientset.Interface,
+	dsQuery *dataselect.DataSelectQuery) (*NodeMetricsList, error) {
+	log.Printf("Getting node resource usage")
+
+	nodeMetricsList, err := metricsClient.MetricsV1beta1().NodeMetricses().List(api.ListOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, ErrMetricsNotAvailable
+		}
+		return nil, err
+	}
+
+	allocatable, err := getNodeAllocatable(client)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]NodeMetric, 0, len(nodeMetricsList.Items))
+	for _, nodeMetrics := range nodeMetricsList.Items {
+		items = append(items, toNodeMetric(nodeMetrics, allocatable[nodeMetrics.Name]))
+	}
+
+	cells := make([]dataselect.GenericDataCell, len(items))
+	for i := range items {
+		cells[i] = nodeMetricCell{items[i]}
+	}
+	selected, filteredTotal := dataselect.GenericDataSelectWithFilter(cells, dsQuery)
+
+	result := make([]NodeMetric, len(selected))
+	for i := range selected {
+		result[i] = selected[i].(nodeMetricCell).NodeMetric
+	}
+
+	return &NodeMetricsList{
+		ListMeta: common.ListMeta{TotalItems: filteredTotal},
+		Items:    result,
+	}, nil
+}
+
+func getNodeAllocatable(client *client.Client) (map[string]ResourceUsage, error) {
+	nodeList, err := client.Nodes().List(api.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	allocatable := make(map[string]ResourceUsage, len(nodeList.Items))
+	for _, node := range nodeList.Items {
+		cpu := node.Status.Allocatable[api.ResourceCPU]
+		memory := node.Status.Allocatable[api.ResourceMemory]
+		allocatable[node.Name] = ResourceUsage{CPUMillicores: cpu.MilliValue(), MemoryBytes: memory.Value()}
+	}
+	return allocatable, nil
+}
+
+func toNodeMetric(nodeMetrics metricsapi.NodeMetrics, allocatable ResourceUsage) NodeMetric {
+	cpu := nodeMetrics.Usage[api.ResourceCPU]
+	memory := nodeMetrics.Usage[api.ResourceMemory]
+	usage := ResourceUsage{CPUMillicores: cpu.MilliValue(), MemoryBytes: memory.Value()}
+
+	metric := NodeMetric{
+		ObjectMeta: common.NewObjectMeta(api.ObjectMeta{Name: nodeMetrics.Name}),
+		TypeMeta:   common.NewTypeMeta(common.ResourceKindNode),
+		Usage:      usage,
+	}
+	if allocatable.CPUMillicores > 0 {
+		metric.CPUUtilization = int64Ptr(usage.CPUMillicores * 100 / allocatable.CPUMillicores)
+	}
+	if allocatable.MemoryBytes > 0 {
+		metric.MemoryUtilization = int64Ptr(usage.MemoryBytes * 100 / allocatable.MemoryBytes)
+	}
+	return metric
+}
+
+// nodeMetricCell adapts NodeMetric to dataselect.GenericDataCell for the "cpu", "memory", "cpu%"
+// and "memory%" sort keys.
+type nodeMetricCell struct {
+	NodeMetric
+}
+
+func (c nodeMetricCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case "cpu":
+		return dataselect.StdComparableInt(c.Usage.CPUMillicores)
+	case "memory":
+		return dataselect.StdComparableInt(c.Usage.MemoryBytes)
+	case "cpu%":
+		if c.CPUUtilization == nil {
+			return dataselect.StdComparableInt(0)
+		}
+		return dataselect.StdComparableInt(*c.CPUUtilization)
+	case "memory%":
+		if c.MemoryUtilization == nil {
+			return dataselect.StdComparableInt(0)
+		}
+		return dataselect.StdComparableInt(*c.MemoryUtilization)
+	case "name":
+		return dataselect.StdComparableString(c.ObjectMeta.Name)
+	default:
+		return nil
+	}
+}
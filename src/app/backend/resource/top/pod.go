@@ -0,0 +1,182 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"log"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	client "k8s.io/kubernetes/pkg/client/unversioned"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset_generated/clientset"
+)
+
+// PodMetric is the point-in-time resource usage of a single pod, its per-container breakdown and
+// - if the pod's containers set resources.requests - the percentage of the request it is using.
+type PodMetric struct {
+	ObjectMeta common.ObjectMeta `json:"objectMeta"`
+	TypeMeta   common.TypeMeta   `json:"typeMeta"`
+
+	Containers []ContainerUsage `json:"containers"`
+	Usage      ResourceUsage    `json:"usage"`
+
+	// CPUUtilization/MemoryUtilization are the usage as a percentage of the pod's total
+	// containers' resources.requests. Nil if any container doesn't set a request for the
+	// resource, matching how the HPA controller computes Utilization targets.
+	CPUUtilization    *int64 `json:"cpuUtilization,omitempty"`
+	MemoryUtilization *int64 `json:"memoryUtilization,omitempty"`
+}
+
+// PodMetricsList is a sorted, filtered and paginated list of PodMetric.
+type PodMetricsList struct {
+	ListMeta common.ListMeta `json:"listMeta"`
+	Items    []PodMetric     `json:"items"`
+}
+
+// GetPodMetricsList returns point-in-time resource usage for every pod in namespace (or the
+// whole cluster if namespace is empty), read from the metrics.k8s.io API. Returns
+// ErrMetricsNotAvailable if that API isn't installed on the cluster.
+func GetPodMetricsList(client *client.Client, metricsClient metricsclientset.Interface, namespace string,
+	dsQuery *dataselect.DataSelectQuery) (*PodMetricsList, error) {
+	log.Printf("Getting pod resource usage in the %s namespace", namespace)
+
+	podMetricsList, err := metricsClient.MetricsV1beta1().PodMetricses(namespace).List(api.ListOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, ErrMetricsNotAvailable
+		}
+		return nil, err
+	}
+
+	requests, err := getPodRequests(client, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]PodMetric, 0, len(podMetricsList.Items))
+	for _, podMetrics := range podMetricsList.Items {
+		items = append(items, toPodMetric(podMetrics, requests[podMetrics.Namespace+"/"+podMetrics.Name]))
+	}
+
+	cells := make([]dataselect.GenericDataCell, len(items))
+	for i := range items {
+		cells[i] = podMetricCell{items[i]}
+	}
+	selected, filteredTotal := dataselect.GenericDataSelectWithFilter(cells, dsQuery)
+
+	result := make([]PodMetric, len(selected))
+	for i := range selected {
+		result[i] = selected[i].(podMetricCell).PodMetric
+	}
+
+	return &PodMetricsList{
+		ListMeta: common.ListMeta{TotalItems: filteredTotal},
+		Items:    result,
+	}, nil
+}
+
+// podRequests is the sum of a pod's containers' resources.requests, plus whether every container
+// actually set each resource - a pod where only some containers set cpu/memory requests has no
+// well-defined utilization target, matching how the HPA controller computes it.
+type podRequests struct {
+	Usage        ResourceUsage
+	AllSetCPU    bool
+	AllSetMemory bool
+}
+
+// getPodRequests returns, for every pod in namespace, the sum of its containers'
+// resources.requests - used to turn raw usage numbers into the cpu%/memory% sort keys.
+func getPodRequests(client *client.Client, namespace string) (map[string]podRequests, error) {
+	podList, err := client.Pods(namespace).List(api.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make(map[string]podRequests, len(podList.Items))
+	for _, pod := range podList.Items {
+		result := podRequests{AllSetCPU: true, AllSetMemory: true}
+		for _, container := range pod.Spec.Containers {
+			cpu, hasCPU := container.Resources.Requests[api.ResourceCPU]
+			memory, hasMemory := container.Resources.Requests[api.ResourceMemory]
+			result.AllSetCPU = result.AllSetCPU && hasCPU
+			result.AllSetMemory = result.AllSetMemory && hasMemory
+			result.Usage = result.Usage.add(ResourceUsage{CPUMillicores: cpu.MilliValue(), MemoryBytes: memory.Value()})
+		}
+		requests[pod.Namespace+"/"+pod.Name] = result
+	}
+	return requests, nil
+}
+
+func toPodMetric(podMetrics metricsapi.PodMetrics, requests podRequests) PodMetric {
+	containers := make([]ContainerUsage, 0, len(podMetrics.Containers))
+	var total ResourceUsage
+	for _, container := range podMetrics.Containers {
+		cpu := container.Usage[api.ResourceCPU]
+		memory := container.Usage[api.ResourceMemory]
+		usage := ResourceUsage{CPUMillicores: cpu.MilliValue(), MemoryBytes: memory.Value()}
+		containers = append(containers, ContainerUsage{Name: container.Name, Usage: usage})
+		total = total.add(usage)
+	}
+
+	metric := PodMetric{
+		ObjectMeta: common.NewObjectMeta(api.ObjectMeta{Name: podMetrics.Name, Namespace: podMetrics.Namespace}),
+		TypeMeta:   common.NewTypeMeta(common.ResourceKindPod),
+		Containers: containers,
+		Usage:      total,
+	}
+	if requests.AllSetCPU && requests.Usage.CPUMillicores > 0 {
+		metric.CPUUtilization = int64Ptr(total.CPUMillicores * 100 / requests.Usage.CPUMillicores)
+	}
+	if requests.AllSetMemory && requests.Usage.MemoryBytes > 0 {
+		metric.MemoryUtilization = int64Ptr(total.MemoryBytes * 100 / requests.Usage.MemoryBytes)
+	}
+	return metric
+}
+
+func int64Ptr(i int64) *int64 { return &i }
+
+// podMetricCell adapts PodMetric to dataselect.GenericDataCell so the generic sort/filter/
+// paginate pipeline can be reused for the "cpu", "memory", "cpu%" and "memory%" sort keys.
+type podMetricCell struct {
+	PodMetric
+}
+
+func (c podMetricCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case "cpu":
+		return dataselect.StdComparableInt(c.Usage.CPUMillicores)
+	case "memory":
+		return dataselect.StdComparableInt(c.Usage.MemoryBytes)
+	case "cpu%":
+		if c.CPUUtilization == nil {
+			return dataselect.StdComparableInt(0)
+		}
+		return dataselect.StdComparableInt(*c.CPUUtilization)
+	case "memory%":
+		if c.MemoryUtilization == nil {
+			return dataselect.StdComparableInt(0)
+		}
+		return dataselect.StdComparableInt(*c.MemoryUtilization)
+	case "name":
+		return dataselect.StdComparableString(c.ObjectMeta.Name)
+	case "namespace":
+		return dataselect.StdComparableString(c.ObjectMeta.Namespace)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+import (
+	"strings"
+	"testing"
+)
+
+// testPodCell is a minimal GenericDataCell standing in for a real resource's data cell. It
+// exposes "name", "phase", "restartCount" and "label:<key>" properties, which is enough to exercise every
+// FilterOp without depending on an actual pod list implementation.
+type testPodCell struct {
+	name         string
+	phase        string
+	restartCount int64
+	labels       map[string]string
+}
+
+func (p testPodCell) GetProperty(name PropertyName) ComparableValue {
+	switch {
+	case name == "name":
+		return StdComparableString(p.name)
+	case name == "phase":
+		return StdComparableString(p.phase)
+	case name == "restartCount":
+		return StdComparableInt(p.restartCount)
+	case strings.HasPrefix(string(name), "label:"):
+		key := strings.TrimPrefix(string(name), "label:")
+		value, ok := p.labels[key]
+		if !ok {
+			return nil
+		}
+		return StdComparableString(value)
+	default:
+		return nil
+	}
+}
+
+func TestNewFilterQuery(t *testing.T) {
+	cases := []struct {
+		info     string
+		raw      []string
+		expected *FilterQuery
+	}{
+		{
+			"nil raw list returns NoFilter",
+			nil,
+			NoFilter,
+		},
+		{
+			"list not a multiple of 3 returns NoFilter",
+			[]string{"name", "eq"},
+			NoFilter,
+		},
+		{
+			"invalid operator returns NoFilter",
+			[]string{"name", "startswith", "nginx"},
+			NoFilter,
+		},
+		{
+			"valid single filter",
+			[]string{"name", "contains", "nginx"},
+			&FilterQuery{FilterByList: []FilterBy{{Property: "name", Op: FilterOpContains, Value: "nginx"}}},
+		},
+		{
+			"valid multiple filters",
+			[]string{"name", "contains", "nginx", "namespace", "eq", "prod"},
+			&FilterQuery{FilterByList: []FilterBy{
+				{Property: "name", Op: FilterOpContains, Value: "nginx"},
+				{Property: "namespace", Op: FilterOpEq, Value: "prod"},
+			}},
+		},
+	}
+
+	for _, c := range cases {
+		actual := NewFilterQuery(c.raw)
+		if len(actual.FilterByList) != len(c.expected.FilterByList) {
+			t.Errorf("%s: expected %#v, got %#v", c.info, c.expected, actual)
+			continue
+		}
+		for i := range actual.FilterByList {
+			if actual.FilterByList[i] != c.expected.FilterByList[i] {
+				t.Errorf("%s: expected %#v, got %#v", c.info, c.expected, actual)
+			}
+		}
+	}
+}
+
+func TestGenericDataSelectWithFilterNumeric(t *testing.T) {
+	cells := []GenericDataCell{
+		testPodCell{name: "pod-1", restartCount: 0},
+		testPodCell{name: "pod-2", restartCount: 3},
+		testPodCell{name: "pod-3", restartCount: 7},
+	}
+
+	dsQuery := NewDataSelectQuery(NoPagination, NoSort, NewFilterQuery([]string{"restartCount", "gt", "2"}), NoMetrics)
+	result, total := GenericDataSelectWithFilter(cells, dsQuery)
+
+	if total != 2 {
+		t.Fatalf("expected 2 pods with restartCount > 2, got %d", total)
+	}
+	names := []string{}
+	for _, cell := range result {
+		names = append(names, cell.(testPodCell).name)
+	}
+	if names[0] != "pod-2" || names[1] != "pod-3" {
+		t.Errorf("expected [pod-2 pod-3], got %v", names)
+	}
+}
+
+func TestGenericDataSelectWithFilterLabels(t *testing.T) {
+	cells := []GenericDataCell{
+		testPodCell{name: "pod-1", labels: map[string]string{"app": "nginx"}},
+		testPodCell{name: "pod-2", labels: map[string]string{"app": "redis"}},
+		testPodCell{name: "pod-3", labels: map[string]string{"app": "nginx"}},
+	}
+
+	dsQuery := NewDataSelectQuery(NoPagination, NoSort, NewFilterQuery([]string{"label:app", "eq", "nginx"}), NoMetrics)
+	result, total := GenericDataSelectWithFilter(cells, dsQuery)
+
+	if total != 2 {
+		t.Fatalf("expected 2 pods labelled app=nginx, got %d", total)
+	}
+	for _, cell := range result {
+		if cell.(testPodCell).labels["app"] != "nginx" {
+			t.Errorf("unexpected pod in filtered result: %#v", cell)
+		}
+	}
+}
+
+// TestGenericDataSelectWithFilterSkipsMetricsForFilteredOutItems simulates a caller that, after
+// running the generic data select pipeline, only requests Heapster metrics for whatever cells
+// survived filtering. It checks that the pipeline's result holds only the cells that match the
+// filter, so a filtered-out cell is never among those the caller fetches metrics for.
+func TestGenericDataSelectWithFilterSkipsMetricsForFilteredOutItems(t *testing.T) {
+	cells := []GenericDataCell{
+		testPodCell{name: "pod-1", restartCount: 0},
+		testPodCell{name: "pod-2", restartCount: 9},
+	}
+
+	dsQuery := NewDataSelectQuery(NoPagination, NoSort, NewFilterQuery([]string{"restartCount", "gt", "5"}), StandardMetrics)
+	result, _ := GenericDataSelectWithFilter(cells, dsQuery)
+
+	namesNeedingMetrics := []string{}
+	for _, cell := range result {
+		namesNeedingMetrics = append(namesNeedingMetrics, cell.(testPodCell).name)
+	}
+
+	if len(namesNeedingMetrics) != 1 || namesNeedingMetrics[0] != "pod-2" {
+		t.Errorf("expected metrics to only be requested for pod-2, got %v", namesNeedingMetrics)
+	}
+}
+
+func TestGenericDataSelectWithFilterIn(t *testing.T) {
+	cells := []GenericDataCell{
+		testPodCell{name: "pod-1", phase: "Running"},
+		testPodCell{name: "pod-2", phase: "Pending"},
+		testPodCell{name: "pod-3", phase: "Failed"},
+	}
+
+	dsQuery := NewDataSelectQuery(NoPagination, NoSort,
+		NewFilterQuery([]string{"phase", "in", "Running|Pending"}), NoMetrics)
+	result, total := GenericDataSelectWithFilter(cells, dsQuery)
+
+	if total != 2 {
+		t.Fatalf("expected 2 pods with phase in [Running, Pending], got %d", total)
+	}
+	names := []string{}
+	for _, cell := range result {
+		names = append(names, cell.(testPodCell).name)
+	}
+	if names[0] != "pod-1" || names[1] != "pod-2" {
+		t.Errorf("expected [pod-1 pod-2], got %v", names)
+	}
+}
+
+func TestGenericDataSelectWithFilterAndPagination(t *testing.T) {
+	cells := []GenericDataCell{
+		testPodCell{name: "pod-1", restartCount: 10},
+		testPodCell{name: "pod-2", restartCount: 0},
+		testPodCell{name: "pod-3", restartCount: 10},
+		testPodCell{name: "pod-4", restartCount: 10},
+	}
+
+	dsQuery := NewDataSelectQuery(NewPaginationQuery(2, 0), NoSort,
+		NewFilterQuery([]string{"restartCount", "gt", "5"}), NoMetrics)
+	result, total := GenericDataSelectWithFilter(cells, dsQuery)
+
+	// 3 pods match the filter, even though only 2 are returned by the first page - the total
+	// must reflect the filtered count, not the paginated one, for pagination to work correctly.
+	if total != 3 {
+		t.Errorf("expected filtered total of 3, got %d", total)
+	}
+	if len(result) != 2 {
+		t.Errorf("expected a page of 2 items, got %d", len(result))
+	}
+}
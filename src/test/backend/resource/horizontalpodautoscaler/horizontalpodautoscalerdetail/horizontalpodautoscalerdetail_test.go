@@ -20,21 +20,156 @@ import (
 
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/horizontalpodautoscaler"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/metric"
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/apis/autoscaling"
 	"k8s.io/kubernetes/pkg/client/unversioned/testclient"
 )
 
-// func GetHorizontalPodAutoscalerDetail(client *client.Client, namespace string, name string) (*HorizontalPodAutoscalerDetail, error) 
+// func GetHorizontalPodAutoscalerDetail(client *client.Client, namespace string, name string) (*HorizontalPodAutoscalerDetail, error)
+
+func int32Ptr(i int32) *int32 { return &i }
+func int64Ptr(i int64) *int64 { return &i }
 
 func TestGetHorizontalPodAutoscalerDetail(t *testing.T) {
 	cases := []struct {
+		info            string
 		namespace, name string
 		expectedActions []string
 		hpa             *autoscaling.HorizontalPodAutoscaler
 		expected        *HorizontalPodAutoscalerDetail
 	}{
 		{
+			"v1 fallback with only a CPU utilization target",
+			"test-namespace", "test-name",
+			[]string{"get"},
+			&autoscaling.HorizontalPodAutoscaler{
+				ObjectMeta: api.ObjectMeta{Name: "test-name"},
+				Spec: autoscaling.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+						Kind: "test-kind",
+						Name: "test-name2",
+					},
+					MaxReplicas:                    3,
+					TargetCPUUtilizationPercentage: int32Ptr(80),
+				},
+				Status: autoscaling.HorizontalPodAutoscalerStatus{
+					CurrentReplicas:                 1,
+					DesiredReplicas:                 2,
+					CurrentCPUUtilizationPercentage: int32Ptr(50),
+				},
+			},
+			&HorizontalPodAutoscalerDetail{
+				ObjectMeta: common.ObjectMeta{Name: "test-name"},
+				TypeMeta:   common.TypeMeta{Kind: common.ResourceKindHorizontalPodAutoscaler},
+				ScaleTargetRef: horizontalpodautoscaler.ScaleTargetRef{
+					Kind: "test-kind",
+					Name: "test-name2",
+				},
+				MaxReplicas:     3,
+				CurrentReplicas: 1,
+				DesiredReplicas: 2,
+				Metrics: []horizontalpodautoscaler.Metric{
+					{
+						Type: autoscaling.ResourceMetricSourceType,
+						Name: "cpu",
+						Target: horizontalpodautoscaler.MetricTarget{
+							Type:               autoscaling.UtilizationMetricType,
+							AverageUtilization: int32Ptr(80),
+						},
+						Current: &horizontalpodautoscaler.CurrentMetricStatus{AverageUtilization: int32Ptr(50)},
+					},
+				},
+				Conditions: []horizontalpodautoscaler.Condition{},
+			},
+		},
+		{
+			"v2 HPA with a Pods metric and an External metric",
+			"test-namespace", "test-name",
+			[]string{"get"},
+			&autoscaling.HorizontalPodAutoscaler{
+				ObjectMeta: api.ObjectMeta{Name: "test-name"},
+				Spec: autoscaling.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+						Kind: "test-kind",
+						Name: "test-name2",
+					},
+					MaxReplicas: 5,
+					Metrics: []autoscaling.MetricSpec{
+						{
+							Type: autoscaling.PodsMetricSourceType,
+							Pods: &autoscaling.PodsMetricSource{
+								Metric: autoscaling.MetricIdentifier{Name: "packets-per-second"},
+								Target: autoscaling.MetricTarget{
+									Type:         autoscaling.AverageValueMetricType,
+									AverageValue: resource.NewQuantity(1000, resource.DecimalSI),
+								},
+							},
+						},
+						{
+							Type: autoscaling.ExternalMetricSourceType,
+							External: &autoscaling.ExternalMetricSource{
+								Metric: autoscaling.MetricIdentifier{Name: "queue-length"},
+								Target: autoscaling.MetricTarget{
+									Type:  autoscaling.ValueMetricType,
+									Value: resource.NewQuantity(30, resource.DecimalSI),
+								},
+							},
+						},
+					},
+				},
+				Status: autoscaling.HorizontalPodAutoscalerStatus{
+					CurrentReplicas: 2,
+					DesiredReplicas: 4,
+					CurrentMetrics: []autoscaling.MetricStatus{
+						{
+							Type: autoscaling.PodsMetricSourceType,
+							Pods: &autoscaling.PodsMetricStatus{
+								Metric:  autoscaling.MetricIdentifier{Name: "packets-per-second"},
+								Current: autoscaling.MetricValueStatus{AverageValue: resource.NewQuantity(1200, resource.DecimalSI)},
+							},
+						},
+					},
+				},
+			},
+			&HorizontalPodAutoscalerDetail{
+				ObjectMeta: common.ObjectMeta{Name: "test-name"},
+				TypeMeta:   common.TypeMeta{Kind: common.ResourceKindHorizontalPodAutoscaler},
+				ScaleTargetRef: horizontalpodautoscaler.ScaleTargetRef{
+					Kind: "test-kind",
+					Name: "test-name2",
+				},
+				MaxReplicas:     5,
+				CurrentReplicas: 2,
+				DesiredReplicas: 4,
+				Metrics: []horizontalpodautoscaler.Metric{
+					{
+						Type: autoscaling.PodsMetricSourceType,
+						Name: "packets-per-second",
+						Target: horizontalpodautoscaler.MetricTarget{
+							Type:         autoscaling.AverageValueMetricType,
+							AverageValue: resource.NewQuantity(1000, resource.DecimalSI),
+						},
+						Current: &horizontalpodautoscaler.CurrentMetricStatus{
+							AverageValue: resource.NewQuantity(1200, resource.DecimalSI),
+						},
+					},
+					{
+						Type: autoscaling.ExternalMetricSourceType,
+						Name: "queue-length",
+						Target: horizontalpodautoscaler.MetricTarget{
+							Type:  autoscaling.ValueMetricType,
+							Value: resource.NewQuantity(30, resource.DecimalSI),
+						},
+					},
+				},
+				Conditions: []horizontalpodautoscaler.Condition{},
+			},
+		},
+		{
+			"v2 HPA with a ContainerResource metric targeting a named container",
 			"test-namespace", "test-name",
 			[]string{"get"},
 			&autoscaling.HorizontalPodAutoscaler{
@@ -45,22 +180,112 @@ func TestGetHorizontalPodAutoscalerDetail(t *testing.T) {
 						Name: "test-name2",
 					},
 					MaxReplicas: 3,
+					Metrics: []autoscaling.MetricSpec{
+						{
+							Type: autoscaling.ContainerResourceMetricSourceType,
+							ContainerResource: &autoscaling.ContainerResourceMetricSource{
+								Name:      api.ResourceCPU,
+								Container: "app",
+								Target: autoscaling.MetricTarget{
+									Type:               autoscaling.UtilizationMetricType,
+									AverageUtilization: int32Ptr(70),
+								},
+							},
+						},
+					},
 				},
 				Status: autoscaling.HorizontalPodAutoscalerStatus{
 					CurrentReplicas: 1,
-					DesiredReplicas: 2,
+					DesiredReplicas: 1,
 				},
 			},
 			&HorizontalPodAutoscalerDetail{
-				ObjectMeta:     common.ObjectMeta{Name: "test-name"},
-				TypeMeta:       common.TypeMeta{Kind: common.ResourceKindHorizontalPodAutoscaler},
+				ObjectMeta: common.ObjectMeta{Name: "test-name"},
+				TypeMeta:   common.TypeMeta{Kind: common.ResourceKindHorizontalPodAutoscaler},
 				ScaleTargetRef: horizontalpodautoscaler.ScaleTargetRef{
 					Kind: "test-kind",
 					Name: "test-name2",
 				},
 				MaxReplicas:     3,
 				CurrentReplicas: 1,
-				DesiredReplicas: 2,
+				DesiredReplicas: 1,
+				Metrics: []horizontalpodautoscaler.Metric{
+					{
+						Type:      autoscaling.ContainerResourceMetricSourceType,
+						Name:      "cpu",
+						Container: "app",
+						Target: horizontalpodautoscaler.MetricTarget{
+							Type:               autoscaling.UtilizationMetricType,
+							AverageUtilization: int32Ptr(70),
+						},
+					},
+				},
+				Conditions: []horizontalpodautoscaler.Condition{},
+			},
+		},
+		{
+			"v2 HPA with scaling behavior and a ScalingLimited condition",
+			"test-namespace", "test-name",
+			[]string{"get"},
+			&autoscaling.HorizontalPodAutoscaler{
+				ObjectMeta: api.ObjectMeta{Name: "test-name"},
+				Spec: autoscaling.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+						Kind: "test-kind",
+						Name: "test-name2",
+					},
+					MaxReplicas: 3,
+					Behavior: &autoscaling.HorizontalPodAutoscalerBehavior{
+						ScaleDown: &autoscaling.HPAScalingRules{
+							StabilizationWindowSeconds: int32Ptr(300),
+							Policies: []autoscaling.HPAScalingPolicy{
+								{Type: autoscaling.PercentScalingPolicy, Value: 50, PeriodSeconds: 60},
+							},
+						},
+					},
+				},
+				Status: autoscaling.HorizontalPodAutoscalerStatus{
+					CurrentReplicas:    3,
+					DesiredReplicas:    3,
+					ObservedGeneration: int64Ptr(2),
+					Conditions: []autoscaling.HorizontalPodAutoscalerCondition{
+						{
+							Type:    autoscaling.ScalingLimited,
+							Status:  api.ConditionTrue,
+							Reason:  "TooFewReplicas",
+							Message: "the desired replica count is more than the maximum replica count",
+						},
+					},
+				},
+			},
+			&HorizontalPodAutoscalerDetail{
+				ObjectMeta: common.ObjectMeta{Name: "test-name"},
+				TypeMeta:   common.TypeMeta{Kind: common.ResourceKindHorizontalPodAutoscaler},
+				ScaleTargetRef: horizontalpodautoscaler.ScaleTargetRef{
+					Kind: "test-kind",
+					Name: "test-name2",
+				},
+				MaxReplicas:        3,
+				CurrentReplicas:    3,
+				DesiredReplicas:    3,
+				Metrics:            []horizontalpodautoscaler.Metric{},
+				ObservedGeneration: int64Ptr(2),
+				Behavior: &horizontalpodautoscaler.Behavior{
+					ScaleDown: &horizontalpodautoscaler.ScalingRules{
+						StabilizationWindowSeconds: int32Ptr(300),
+						Policies: []horizontalpodautoscaler.ScalingPolicy{
+							{Type: autoscaling.PercentScalingPolicy, Value: 50, PeriodSeconds: 60},
+						},
+					},
+				},
+				Conditions: []horizontalpodautoscaler.Condition{
+					{
+						Type:    autoscaling.ScalingLimited,
+						Status:  api.ConditionTrue,
+						Reason:  "TooFewReplicas",
+						Message: "the desired replica count is more than the maximum replica count",
+					},
+				},
 			},
 		},
 	}
@@ -72,21 +297,75 @@ func TestGetHorizontalPodAutoscalerDetail(t *testing.T) {
 
 		actions := fakeClient.Actions()
 		if len(actions) != len(c.expectedActions) {
-			t.Errorf("Unexpected actions: %v, expected %d actions got %d", actions,
+			t.Errorf("%s: Unexpected actions: %v, expected %d actions got %d", c.info, actions,
 				len(c.expectedActions), len(actions))
 			continue
 		}
 
 		for i, verb := range c.expectedActions {
 			if actions[i].GetVerb() != verb {
-				t.Errorf("Unexpected action: %+v, expected %s",
-					actions[i], verb)
+				t.Errorf("%s: Unexpected action: %+v, expected %s", c.info, actions[i], verb)
 			}
 		}
 
 		if !reflect.DeepEqual(actual, c.expected) {
-			t.Errorf("GetEvents(client,heapsterClient,%#v, %#v) == \ngot: %#v, \nexpected %#v",
-				c.namespace, c.name, actual, c.expected)
+			t.Errorf("%s: GetHorizontalPodAutoscalerDetail(client,%#v,%#v) == \ngot: %#v, \nexpected %#v",
+				c.info, c.namespace, c.name, actual, c.expected)
 		}
 	}
-}
\ No newline at end of file
+}
+
+// liveValueMetricClient is a fake metric.MetricClient that always returns the same value, used to
+// verify GetHorizontalPodAutoscalerDetail fills in a metric's Current from its live provider when
+// the HPA controller hasn't reported a status for it yet.
+type liveValueMetricClient struct {
+	value metric.MetricValue
+}
+
+func (c liveValueMetricClient) GetMetric(metricName string, namespace string,
+	selector *unversioned.LabelSelector, target *metric.ObjectReference) (metric.MetricValue, error) {
+	return c.value, nil
+}
+
+func TestGetHorizontalPodAutoscalerDetailWithLiveMetrics(t *testing.T) {
+	value := resource.NewQuantity(250, resource.DecimalSI)
+	metric.RegisterMetricClient(metric.CustomProvider, liveValueMetricClient{
+		value: metric.MetricValue{AverageValue: value},
+	})
+
+	hpa := &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: api.ObjectMeta{Name: "test-name"},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{Kind: "test-kind", Name: "test-name2"},
+			MaxReplicas:    5,
+			Metrics: []autoscaling.MetricSpec{
+				{
+					Type: autoscaling.PodsMetricSourceType,
+					Pods: &autoscaling.PodsMetricSource{
+						Metric: autoscaling.MetricIdentifier{Name: "packets-per-second"},
+						Target: autoscaling.MetricTarget{
+							Type:         autoscaling.AverageValueMetricType,
+							AverageValue: resource.NewQuantity(1000, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+		Status: autoscaling.HorizontalPodAutoscalerStatus{CurrentReplicas: 2, DesiredReplicas: 4},
+	}
+	fakeClient := testclient.NewSimpleFake(hpa)
+
+	actual, err := GetHorizontalPodAutoscalerDetail(fakeClient, "test-namespace", "test-name")
+	if err != nil {
+		t.Fatalf("GetHorizontalPodAutoscalerDetail() == _, %s, expected nil error", err)
+	}
+
+	if len(actual.Metrics) != 1 || actual.Metrics[0].Current == nil {
+		t.Fatalf("GetHorizontalPodAutoscalerDetail().Metrics == %#v, expected one metric with Current set",
+			actual.Metrics)
+	}
+	if actual.Metrics[0].Current.AverageValue.Cmp(*value) != 0 {
+		t.Errorf("GetHorizontalPodAutoscalerDetail().Metrics[0].Current.AverageValue == %s, expected %s",
+			actual.Metrics[0].Current.AverageValue, value)
+	}
+}
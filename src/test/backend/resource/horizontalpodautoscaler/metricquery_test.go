@@ -0,0 +1,104 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/metric"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
+)
+
+func TestToMetricQueryProvider(t *testing.T) {
+	cases := []struct {
+		info     string
+		spec     autoscaling.MetricSpec
+		expected metric.Provider
+	}{
+		{
+			"Resource metric uses Heapster",
+			autoscaling.MetricSpec{
+				Type:     autoscaling.ResourceMetricSourceType,
+				Resource: &autoscaling.ResourceMetricSource{Name: "cpu"},
+			},
+			metric.HeapsterProvider,
+		},
+		{
+			"Pods metric uses custom.metrics.k8s.io",
+			autoscaling.MetricSpec{
+				Type: autoscaling.PodsMetricSourceType,
+				Pods: &autoscaling.PodsMetricSource{Metric: autoscaling.MetricIdentifier{Name: "packets-per-second"}},
+			},
+			metric.CustomProvider,
+		},
+		{
+			"External metric uses external.metrics.k8s.io",
+			autoscaling.MetricSpec{
+				Type:     autoscaling.ExternalMetricSourceType,
+				External: &autoscaling.ExternalMetricSource{Metric: autoscaling.MetricIdentifier{Name: "queue-length"}},
+			},
+			metric.ExternalProvider,
+		},
+	}
+
+	for _, c := range cases {
+		query, ok := ToMetricQuery(c.spec)
+		if !ok {
+			t.Errorf("%s: ToMetricQuery(%#v) == _, false, expected true", c.info, c.spec)
+			continue
+		}
+		if query.Provider != c.expected {
+			t.Errorf("%s: ToMetricQuery(%#v).Provider == %s, expected %s", c.info, c.spec, query.Provider, c.expected)
+		}
+	}
+}
+
+func TestToMetricQueryUnknownSource(t *testing.T) {
+	if _, ok := ToMetricQuery(autoscaling.MetricSpec{Type: autoscaling.ObjectMetricSourceType}); ok {
+		t.Errorf("ToMetricQuery with a nil Object source == _, true, expected false")
+	}
+}
+
+func TestMetricQueryForObjectMetric(t *testing.T) {
+	spec := autoscaling.HorizontalPodAutoscalerSpec{
+		Metrics: []autoscaling.MetricSpec{
+			{
+				Type: autoscaling.ObjectMetricSourceType,
+				Object: &autoscaling.ObjectMetricSource{
+					DescribedObject: autoscaling.CrossVersionObjectReference{Kind: "Ingress", Name: "main"},
+					Metric:          autoscaling.MetricIdentifier{Name: "requests-per-second"},
+				},
+			},
+		},
+	}
+	m := Metric{Type: autoscaling.ObjectMetricSourceType, Name: "requests-per-second"}
+
+	query, ok := MetricQueryFor(spec, m)
+	if !ok {
+		t.Fatalf("MetricQueryFor(%#v, %#v) == _, false, expected true", spec, m)
+	}
+	if query.Provider != metric.CustomProvider {
+		t.Errorf("MetricQueryFor(...).Provider == %s, expected %s", query.Provider, metric.CustomProvider)
+	}
+	if query.TargetRef == nil || query.TargetRef.Kind != "Ingress" || query.TargetRef.Name != "main" {
+		t.Errorf("MetricQueryFor(...).TargetRef == %#v, expected {Kind: Ingress, Name: main}", query.TargetRef)
+	}
+}
+
+func TestMetricQueryForNoMatch(t *testing.T) {
+	if _, ok := MetricQueryFor(autoscaling.HorizontalPodAutoscalerSpec{}, Metric{Name: "cpu"}); ok {
+		t.Errorf("MetricQueryFor with no matching spec.Metrics entry == _, true, expected false")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+type fakeMetricClient struct{}
+
+func (fakeMetricClient) GetMetric(metricName string, namespace string,
+	selector *unversioned.LabelSelector, target *ObjectReference) (MetricValue, error) {
+	return MetricValue{}, nil
+}
+
+func TestClientForUnregisteredProvider(t *testing.T) {
+	if _, ok := ClientFor(ExternalProvider); ok {
+		t.Errorf("ClientFor(ExternalProvider) == _, true, expected false before any client is registered")
+	}
+}
+
+func TestRegisterMetricClient(t *testing.T) {
+	client := fakeMetricClient{}
+	RegisterMetricClient(CustomProvider, client)
+
+	got, ok := ClientFor(CustomProvider)
+	if !ok {
+		t.Fatalf("ClientFor(CustomProvider) == _, false, expected true after RegisterMetricClient")
+	}
+	if got != client {
+		t.Errorf("ClientFor(CustomProvider) == %#v, expected %#v", got, client)
+	}
+}
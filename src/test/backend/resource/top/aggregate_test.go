@@ -0,0 +1,78 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func TestAggregatePodUsage(t *testing.T) {
+	items := []PodMetric{
+		{Usage: ResourceUsage{CPUMillicores: 100, MemoryBytes: 1000}},
+		{Usage: ResourceUsage{CPUMillicores: 300, MemoryBytes: 3000}},
+	}
+
+	sum := AggregatePodUsage(items, SumAggregation)
+	if sum.CPUMillicores != 400 || sum.MemoryBytes != 4000 {
+		t.Errorf("expected sum {400 4000}, got %+v", sum)
+	}
+
+	avg := AggregatePodUsage(items, AverageAggregation)
+	if avg.CPUMillicores != 200 || avg.MemoryBytes != 2000 {
+		t.Errorf("expected average {200 2000}, got %+v", avg)
+	}
+}
+
+func TestAggregatePodUsageEmpty(t *testing.T) {
+	avg := AggregatePodUsage(nil, AverageAggregation)
+	if avg.CPUMillicores != 0 || avg.MemoryBytes != 0 {
+		t.Errorf("expected zero-value ResourceUsage for empty input, got %+v", avg)
+	}
+}
+
+func TestPodMetricCellGetProperty(t *testing.T) {
+	utilization := int64(42)
+	cell := podMetricCell{PodMetric{
+		Usage:          ResourceUsage{CPUMillicores: 250, MemoryBytes: 2048},
+		CPUUtilization: &utilization,
+	}}
+
+	cases := []struct {
+		property dataselect.PropertyName
+		expected dataselect.StdComparableInt
+	}{
+		{"cpu", 250},
+		{"memory", 2048},
+		{"cpu%", 42},
+		{"memory%", 0},
+	}
+
+	for _, c := range cases {
+		actual, ok := cell.GetProperty(c.property).(dataselect.StdComparableInt)
+		if !ok {
+			t.Errorf("property %s: expected a StdComparableInt", c.property)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("property %s: expected %d, got %d", c.property, c.expected, actual)
+		}
+	}
+
+	if cell.GetProperty("bogus") != nil {
+		t.Errorf("expected nil for an unsupported property")
+	}
+}
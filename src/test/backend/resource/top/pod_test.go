@@ -0,0 +1,76 @@
+// Copyright 2015 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	metricsapi "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func TestToPodMetricRequiresEveryContainerToSetTheRequest(t *testing.T) {
+	podMetrics := metricsapi.PodMetrics{
+		Containers: []metricsapi.ContainerMetrics{
+			{Name: "app", Usage: api.ResourceList{
+				api.ResourceCPU:    *resource.NewMilliQuantity(100, resource.DecimalSI),
+				api.ResourceMemory: *resource.NewQuantity(1000, resource.DecimalSI),
+			}},
+			{Name: "sidecar", Usage: api.ResourceList{
+				api.ResourceCPU:    *resource.NewMilliQuantity(50, resource.DecimalSI),
+				api.ResourceMemory: *resource.NewQuantity(500, resource.DecimalSI),
+			}},
+		},
+	}
+
+	// Only one of the pod's two containers sets a CPU request, and neither sets a memory
+	// request - so both utilization percentages must be left nil rather than computed against a
+	// partial total.
+	requests := podRequests{
+		Usage:        ResourceUsage{CPUMillicores: 200, MemoryBytes: 0},
+		AllSetCPU:    false,
+		AllSetMemory: false,
+	}
+
+	metric := toPodMetric(podMetrics, requests)
+	if metric.CPUUtilization != nil {
+		t.Errorf("expected nil CPUUtilization when not every container sets a cpu request, got %d",
+			*metric.CPUUtilization)
+	}
+	if metric.MemoryUtilization != nil {
+		t.Errorf("expected nil MemoryUtilization when no container sets a memory request, got %d",
+			*metric.MemoryUtilization)
+	}
+}
+
+func TestToPodMetricComputesUtilizationWhenEveryContainerSetsTheRequest(t *testing.T) {
+	podMetrics := metricsapi.PodMetrics{
+		Containers: []metricsapi.ContainerMetrics{
+			{Name: "app", Usage: api.ResourceList{
+				api.ResourceCPU: *resource.NewMilliQuantity(150, resource.DecimalSI),
+			}},
+		},
+	}
+	requests := podRequests{
+		Usage:     ResourceUsage{CPUMillicores: 300},
+		AllSetCPU: true,
+	}
+
+	metric := toPodMetric(podMetrics, requests)
+	if metric.CPUUtilization == nil || *metric.CPUUtilization != 50 {
+		t.Errorf("expected CPUUtilization of 50, got %v", metric.CPUUtilization)
+	}
+}